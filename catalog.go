@@ -0,0 +1,96 @@
+package problem
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Definition is a reusable problem type registered in a Catalog. Detail is
+// a text/template string executed against the params passed to Catalog.New,
+// so a single definition can produce per-instance detail messages.
+type Definition struct {
+	Type   string
+	Title  string
+	Status int
+	Detail string
+}
+
+// Catalog is a registry of Definitions keyed by a short code, turning the
+// free-form Type(uri) option into a managed vocabulary that Handler can
+// serve documentation for.
+type Catalog struct {
+	definitions map[string]Definition
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{definitions: make(map[string]Definition)}
+}
+
+// Register adds or replaces the Definition for code.
+func (c *Catalog) Register(code string, def Definition) {
+	c.definitions[code] = def
+}
+
+// New returns a fully-populated Problem for code, rendering Detail as a
+// text/template against params. If params contains a "request_id" string,
+// it is used as the Problem's instance.
+func (c *Catalog) New(code string, params map[string]any) *Problem {
+	def, ok := c.definitions[code]
+	if !ok {
+		return Of(http.StatusInternalServerError).Append(Detail("problem: unknown catalog code " + code))
+	}
+
+	detail := def.Detail
+	if tmpl, err := texttemplate.New(code).Parse(def.Detail); err == nil {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, params); err == nil {
+			detail = buf.String()
+		}
+	}
+
+	p := New(Type(def.Type), Title(def.Title), Status(def.Status), Detail(detail))
+	if id, ok := params["request_id"].(string); ok {
+		p.Append(Instance(id))
+	}
+	return p
+}
+
+// Handler serves each registered Definition at /problems/{code}, so the
+// type URIs in Problems produced by this Catalog resolve to documentation
+// as recommended by RFC 7807. Requests accepting application/json (or
+// application/problem+json) receive the raw Definition as a Problem;
+// everything else receives an HTML page.
+func (c *Catalog) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := strings.TrimPrefix(r.URL.Path, "/problems/")
+		def, ok := c.definitions[code]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if strings.Contains(r.Header.Get("Accept"), "json") {
+			p := New(Type(def.Type), Title(def.Title), Status(def.Status), Detail(def.Detail))
+			p.WriteHeaderTo(w)
+			w.Write(p.JSON())
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		catalogPage.Execute(w, def)
+	})
+}
+
+var catalogPage = template.Must(template.New("problem-catalog").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Status: {{.Status}}</p>
+<p>{{.Detail}}</p>
+</body>
+</html>
+`))
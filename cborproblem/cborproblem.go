@@ -0,0 +1,39 @@
+// Package cborproblem serves Problems as application/problem+cbor, a
+// compact binary encoding better suited than JSON to constrained clients
+// (e.g. IoT devices, low-bandwidth links). Importing it registers a CBOR
+// marshaler with the problem package, so (*problem.Problem).Negotiate
+// also starts honoring an Accept: application/problem+cbor header.
+package cborproblem
+
+import (
+	"net/http"
+
+	"github.com/cheetahbyte/problems"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const MediaType = "application/problem+cbor"
+
+func init() {
+	problem.RegisterCBORMarshaler(cbor.Marshal)
+}
+
+// WriteTo marshals p as application/problem+cbor and writes it to w,
+// mirroring (*problem.Problem).WriteTo's status-code handling.
+func WriteTo(p *problem.Problem, w http.ResponseWriter) (int, error) {
+	w.Header().Set("Content-Type", MediaType)
+
+	code := http.StatusInternalServerError
+	if s, ok := p.Get("status"); ok {
+		if c, ok := s.(int); ok {
+			code = c
+		}
+	}
+	w.WriteHeader(code)
+
+	b, err := cbor.Marshal(p.Data())
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(b)
+}
@@ -0,0 +1,108 @@
+// Package httpx adapts the problem package to net/http handlers, converting
+// panics and returned errors into application/problem+json responses.
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/cheetahbyte/problems"
+)
+
+// HandlerFunc is an http.Handler variant that can return an error instead of
+// writing a response body directly. Errors returned from a HandlerFunc are
+// resolved to a *problem.Problem by a Mapper and written to the client.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Factory builds a *problem.Problem from an error matched by a Mapper entry.
+type Factory func(err error) *problem.Problem
+
+// Mapper resolves errors returned by a HandlerFunc to a *problem.Problem.
+// Entries are tried in registration order; the first match wins.
+type Mapper struct {
+	entries  []entry
+	fallback Factory
+}
+
+type entry struct {
+	match   func(error) bool
+	factory Factory
+}
+
+// DefaultMapper is used by Handle when no Mapper is supplied explicitly.
+var DefaultMapper = NewMapper()
+
+// NewMapper returns an empty Mapper that falls back to a generic 500
+// Problem for any error it cannot resolve.
+func NewMapper() *Mapper {
+	return &Mapper{
+		fallback: func(err error) *problem.Problem {
+			return problem.Of(http.StatusInternalServerError).Append(problem.WrapPublic(err))
+		},
+	}
+}
+
+// Register adds a mapping from errors matching predicate to the Problem
+// produced by factory. Predicates are evaluated in registration order.
+func (m *Mapper) Register(predicate func(error) bool, factory Factory) {
+	m.entries = append(m.entries, entry{match: predicate, factory: factory})
+}
+
+// RegisterIs registers a mapping for errors satisfying errors.Is(err, target).
+func (m *Mapper) RegisterIs(target error, factory Factory) {
+	m.Register(func(err error) bool { return errors.Is(err, target) }, factory)
+}
+
+// RegisterAs registers a mapping for errors satisfying errors.As(err, target).
+// target must be a non-nil pointer, following errors.As conventions; it is
+// only used to determine the type being matched.
+func (m *Mapper) RegisterAs(target any, factory Factory) {
+	typ := reflect.TypeOf(target).Elem()
+	m.Register(func(err error) bool { return errors.As(err, reflect.New(typ).Interface()) }, factory)
+}
+
+// Fallback overrides the Problem produced for errors matched by no entry.
+func (m *Mapper) Fallback(factory Factory) {
+	m.fallback = factory
+}
+
+// Resolve returns the Problem for err, using the fallback factory if no
+// entry matches.
+func (m *Mapper) Resolve(err error) *problem.Problem {
+	for _, e := range m.entries {
+		if e.match(err) {
+			return e.factory(err)
+		}
+	}
+	return m.fallback(err)
+}
+
+// Handle adapts h into an http.Handler using DefaultMapper, recovering
+// panics and writing any returned error as a Problem response.
+func Handle(h HandlerFunc) http.Handler {
+	return DefaultMapper.Handle(h)
+}
+
+// Handle adapts h into an http.Handler using m, recovering panics and
+// writing any returned error as a Problem response.
+func (m *Mapper) Handle(h HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err, ok := rec.(error)
+				if !ok {
+					err = fmt.Errorf("httpx: recovered panic: %v", rec)
+				}
+				log.Printf("httpx: recovered panic: %v", rec)
+				m.Resolve(err).WriteTo(w)
+			}
+		}()
+
+		if err := h(w, r); err != nil {
+			m.Resolve(err).WriteTo(w)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cheetahbyte/problems"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestMapperResolve(t *testing.T) {
+	m := NewMapper()
+	m.RegisterIs(errNotFound, func(err error) *problem.Problem {
+		return problem.Of(http.StatusNotFound)
+	})
+
+	t.Run("matched entry wins", func(t *testing.T) {
+		p := m.Resolve(errNotFound)
+		if status, _ := p.Get("status"); status != http.StatusNotFound {
+			t.Fatalf("status = %v, want %d", status, http.StatusNotFound)
+		}
+	})
+
+	t.Run("unmatched error falls back", func(t *testing.T) {
+		p := m.Resolve(errors.New("boom"))
+		if status, _ := p.Get("status"); status != http.StatusInternalServerError {
+			t.Fatalf("status = %v, want %d", status, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("first registered match wins", func(t *testing.T) {
+		wrapped := errors.New("wrapped not found")
+		m2 := NewMapper()
+		m2.Register(func(error) bool { return true }, func(error) *problem.Problem {
+			return problem.Of(http.StatusTeapot)
+		})
+		m2.RegisterIs(wrapped, func(error) *problem.Problem {
+			return problem.Of(http.StatusNotFound)
+		})
+		p := m2.Resolve(wrapped)
+		if status, _ := p.Get("status"); status != http.StatusTeapot {
+			t.Fatalf("status = %v, want %d (first entry should win)", status, http.StatusTeapot)
+		}
+	})
+}
+
+func TestHandlePanicRecovery(t *testing.T) {
+	h := Handle(func(w http.ResponseWriter, r *http.Request) error {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != problem.MediaType {
+		t.Fatalf("Content-Type = %q, want %q", ct, problem.MediaType)
+	}
+}
+
+func TestHandleReturnedError(t *testing.T) {
+	m := NewMapper()
+	m.RegisterIs(errNotFound, func(err error) *problem.Problem {
+		return problem.Of(http.StatusNotFound)
+	})
+
+	h := m.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		return errNotFound
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,54 @@
+// Package i18nproblem translates a Problem's title and detail through an
+// x/text message catalog and an Accept-Language-derived tag, so a single
+// problem definition registered in one language can be served correctly
+// to clients in any locale the catalog covers.
+package i18nproblem
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/cheetahbyte/problems"
+)
+
+// Translate returns an Option that looks up the Problem's current title
+// and detail as message keys in cat for tag, replacing them with the
+// translated strings if a translation exists. The key looked up is the
+// text with every '%' doubled (see escapePercent), so catalog entries for
+// text containing '%' must be registered under that escaped form.
+func Translate(cat catalog.Catalog, tag language.Tag) problem.Option {
+	printer := message.NewPrinter(tag, message.Catalog(cat))
+	return problem.OptionFunc(func(p *problem.Problem) {
+		if title, ok := p.Get("title"); ok {
+			if s, ok := title.(string); ok {
+				p.Append(problem.Title(printer.Sprintf(escapePercent(s))))
+			}
+		}
+		if detail, ok := p.Get("detail"); ok {
+			if s, ok := detail.(string); ok {
+				p.Append(problem.Detail(printer.Sprintf(escapePercent(s))))
+			}
+		}
+	})
+}
+
+// escapePercent escapes literal '%' in s so Sprintf's verb-parsing treats
+// it as a literal rather than the start of a (missing) format verb, while
+// still performing the catalog lookup that only Sprintf triggers.
+func escapePercent(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// TagFromAcceptLanguage parses an HTTP Accept-Language header and returns
+// its best-matched language tag, or language.Und if header is empty or
+// unparseable.
+func TagFromAcceptLanguage(header string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return language.Und
+	}
+	return tags[0]
+}
@@ -0,0 +1,50 @@
+package i18nproblem
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/cheetahbyte/problems"
+)
+
+func TestTranslateNoCatalogEntryPreservesPercent(t *testing.T) {
+	b := catalog.NewBuilder()
+
+	want := "Disk usage at 95% capacity"
+	p := problem.New(problem.Title(want), Translate(b, language.English))
+
+	got, _ := p.Get("title")
+	if got != want {
+		t.Fatalf("title = %q, want %q (literal %% must survive untranslated)", got, want)
+	}
+}
+
+func TestTranslateAppliesCatalogEntry(t *testing.T) {
+	b := catalog.NewBuilder()
+	source := "Disk usage at 95%% capacity"
+	translated := "Utilisation du disque à 95%% capacité"
+	if err := b.SetString(language.French, source, translated); err != nil {
+		t.Fatal(err)
+	}
+
+	p := problem.New(problem.Title("Disk usage at 95% capacity"), Translate(b, language.French))
+
+	got, _ := p.Get("title")
+	want := "Utilisation du disque à 95% capacité"
+	if got != want {
+		t.Fatalf("title = %q, want %q", got, want)
+	}
+}
+
+func TestTagFromAcceptLanguage(t *testing.T) {
+	tag := TagFromAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8")
+	if tag != language.Make("fr-CH") {
+		t.Fatalf("tag = %v, want fr-CH", tag)
+	}
+
+	if tag := TagFromAcceptLanguage(""); tag != language.Und {
+		t.Fatalf("tag = %v, want Und for empty header", tag)
+	}
+}
@@ -0,0 +1,49 @@
+// Package otelproblem stamps Problems with the trace and span ID of the
+// OpenTelemetry span active when they were created, and echoes a
+// traceparent response header, so a Problem surfaced by one service can
+// be traced back to the request that produced it in another.
+package otelproblem
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cheetahbyte/problems"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparentKey = "traceparent"
+
+// FromContext returns an Option that injects the active span's trace ID
+// and span ID from ctx as "trace_id" and "span_id" extensions, and records
+// a W3C traceparent value that WriteTo echoes back as a response header.
+// If ctx carries no valid span, it is a no-op.
+func FromContext(ctx context.Context) problem.Option {
+	span := trace.SpanContextFromContext(ctx)
+	if !span.IsValid() {
+		return problem.OptionFunc(func(*problem.Problem) {})
+	}
+
+	traceparent := fmt.Sprintf("00-%s-%s-%s", span.TraceID(), span.SpanID(), span.TraceFlags())
+	return problem.OptionFunc(func(p *problem.Problem) {
+		p.Append(
+			problem.Ext("trace_id", span.TraceID().String()),
+			problem.Ext("span_id", span.SpanID().String()),
+			problem.Ext(traceparentKey, traceparent),
+		)
+	})
+}
+
+// WriteTo writes p to w like (*problem.Problem).WriteTo, additionally
+// echoing the traceparent recorded by FromContext as a response header,
+// if present, so the Problem can be correlated with the span that
+// produced it end-to-end.
+func WriteTo(p *problem.Problem, w http.ResponseWriter) (int, error) {
+	if tp, ok := p.Get(traceparentKey); ok {
+		if s, ok := tp.(string); ok {
+			w.Header().Set(traceparentKey, s)
+		}
+	}
+	return p.WriteTo(w)
+}
@@ -5,15 +5,22 @@ import (
 	"errors"
 	"maps"
 	"net/http"
+	"sort"
 )
 
 const MediaType = "application/problem+json"
+const MediaTypeXML = "application/problem+xml"
 
 type Option interface{ apply(*Problem) }
 
-type optionFunc func(*Problem)
+// OptionFunc adapts a plain function into an Option, for extension
+// packages that build their own Options on top of Problem's exported
+// methods without needing access to its unexported fields.
+type OptionFunc func(*Problem)
 
-func (f optionFunc) apply(p *Problem) { f(p) }
+func (f OptionFunc) apply(p *Problem) { f(p) }
+
+type optionFunc = OptionFunc
 
 type Problem struct {
 	data  map[string]any
@@ -212,6 +219,18 @@ func ensureMap(p *Problem) {
 	}
 }
 
+func (p *Problem) sortedKeys() []string {
+	if p == nil || len(p.data) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(p.data))
+	for k := range p.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func asStatusCode(v any) (int, bool) {
 	switch s := v.(type) {
 	case int:
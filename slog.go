@@ -0,0 +1,24 @@
+package problem
+
+import (
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer, so a *Problem passed to slog.Error
+// (or any other slog call) renders as structured key/value pairs instead
+// of its JSON string form.
+func (p *Problem) LogValue() slog.Value {
+	if p == nil {
+		return slog.GroupValue()
+	}
+
+	keys := p.sortedKeys()
+	attrs := make([]slog.Attr, 0, len(keys)+1)
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, p.data[k]))
+	}
+	if p.cause != nil {
+		attrs = append(attrs, slog.String("cause", p.cause.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
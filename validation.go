@@ -0,0 +1,90 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+type Violation struct {
+	Field  string `json:"field" xml:"field"`
+	Reason string `json:"reason" xml:"reason"`
+	Code   string `json:"code,omitempty" xml:"code,omitempty"`
+	Value  any    `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+func InvalidParams(violations ...Violation) Option {
+	return optionFunc(func(p *Problem) {
+		ensureMap(p)
+		p.data["invalid-params"] = violations
+	})
+}
+
+// Violator lets a user-defined error type report its own violations,
+// bypassing FromValidator's best-effort detection of popular shapes.
+type Violator interface {
+	Violations() []Violation
+}
+
+// fieldError matches the method set of go-playground/validator's
+// FieldError, detected structurally so this package need not depend on it.
+type fieldError interface {
+	Field() string
+	Tag() string
+	Error() string
+}
+
+func FromValidator(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	p := New(
+		Status(http.StatusUnprocessableEntity),
+		Type("urn:ietf:rfc:7807#validation"),
+		Title("Validation Failed"),
+		Wrap(err),
+	)
+
+	var violator Violator
+	if errors.As(err, &violator) {
+		return p.Append(InvalidParams(violator.Violations()...))
+	}
+
+	var ute *json.UnmarshalTypeError
+	if errors.As(err, &ute) {
+		return p.Append(InvalidParams(Violation{
+			Field:  ute.Field,
+			Reason: ute.Error(),
+			Code:   "type_mismatch",
+		}))
+	}
+
+	if violations, ok := violationsFromFieldErrors(err); ok {
+		return p.Append(InvalidParams(violations...))
+	}
+
+	return p.Append(Detail(err.Error()))
+}
+
+func violationsFromFieldErrors(err error) ([]Violation, bool) {
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return nil, false
+	}
+
+	violations := make([]Violation, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		fe, ok := v.Index(i).Interface().(fieldError)
+		if !ok {
+			return nil, false
+		}
+		violations = append(violations, Violation{
+			Field:  fe.Field(),
+			Reason: fe.Error(),
+			Code:   fe.Tag(),
+		})
+	}
+	return violations, true
+}
@@ -0,0 +1,78 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFromValidatorNil(t *testing.T) {
+	if p := FromValidator(nil); p != nil {
+		t.Fatalf("FromValidator(nil) = %v, want nil", p)
+	}
+}
+
+type stubViolator struct{ violations []Violation }
+
+func (s stubViolator) Error() string           { return "invalid" }
+func (s stubViolator) Violations() []Violation { return s.violations }
+
+func TestFromValidatorViolator(t *testing.T) {
+	want := []Violation{{Field: "name", Reason: "required", Code: "required"}}
+	p := FromValidator(stubViolator{violations: want})
+
+	got, ok := p.Get("invalid-params")
+	if !ok {
+		t.Fatal("invalid-params not set")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid-params = %v, want %v", got, want)
+	}
+}
+
+func TestFromValidatorViolatorWrapped(t *testing.T) {
+	want := []Violation{{Field: "name", Reason: "required", Code: "required"}}
+	wrapped := fmt.Errorf("decoding failed: %w", stubViolator{violations: want})
+
+	p := FromValidator(wrapped)
+
+	got, ok := p.Get("invalid-params")
+	if !ok {
+		t.Fatal("invalid-params not set for wrapped Violator error")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid-params = %v, want %v", got, want)
+	}
+}
+
+func TestFromValidatorUnmarshalTypeErrorWrapped(t *testing.T) {
+	ute := &json.UnmarshalTypeError{Field: "age", Type: reflect.TypeOf(0)}
+	wrapped := fmt.Errorf("decoding failed: %w", ute)
+
+	p := FromValidator(wrapped)
+
+	got, ok := p.Get("invalid-params")
+	if !ok {
+		t.Fatal("invalid-params not set for wrapped UnmarshalTypeError")
+	}
+	violations, ok := got.([]Violation)
+	if !ok || len(violations) != 1 {
+		t.Fatalf("invalid-params = %v, want one Violation", got)
+	}
+	if violations[0].Field != "age" || violations[0].Code != "type_mismatch" {
+		t.Fatalf("violation = %+v, want Field=age Code=type_mismatch", violations[0])
+	}
+}
+
+func TestFromValidatorFallback(t *testing.T) {
+	err := fmt.Errorf("something went wrong")
+	p := FromValidator(err)
+
+	if _, ok := p.Get("invalid-params"); ok {
+		t.Fatal("invalid-params should not be set for an unrecognized error")
+	}
+	if detail, _ := p.Get("detail"); detail != err.Error() {
+		t.Fatalf("detail = %v, want %v", detail, err.Error())
+	}
+}
@@ -0,0 +1,107 @@
+package problem
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+// MarshalXML renders the Problem's members as child elements of a
+// <problem> root, so it can be served as application/problem+xml per
+// RFC 7807 §3.
+func (p *Problem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "problem"}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if p != nil {
+		for _, k := range p.sortedKeys() {
+			if err := e.EncodeElement(p.data[k], xml.StartElement{Name: xml.Name{Local: k}}); err != nil {
+				return err
+			}
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+const MediaTypeCBOR = "application/problem+cbor"
+
+// CBORMarshaler matches the signature of encoding-style Marshal functions
+// such as github.com/fxamacker/cbor/v2.Marshal.
+type CBORMarshaler func(v any) ([]byte, error)
+
+var cborMarshaler CBORMarshaler
+
+// RegisterCBORMarshaler installs the CBOR marshaler Negotiate uses for
+// requests accepting application/problem+cbor. cborproblem calls this from
+// an init function, so this package itself never depends on a CBOR
+// library unless that package is imported.
+func RegisterCBORMarshaler(m CBORMarshaler) {
+	cborMarshaler = m
+}
+
+// Negotiate writes p to w as application/problem+cbor, application/problem+xml,
+// or application/problem+json depending on r's Accept header, preferring
+// whichever of those p's caller has made available: CBOR requires
+// RegisterCBORMarshaler to have been called (typically by importing
+// cborproblem for its side effect), and falls back to XML or JSON
+// otherwise.
+func (p *Problem) Negotiate(w http.ResponseWriter, r *http.Request) (int, error) {
+	accept := r.Header.Get("Accept")
+	switch {
+	case cborMarshaler != nil && strings.Contains(accept, MediaTypeCBOR):
+		return p.writeCBOR(w, http.StatusInternalServerError)
+	case acceptsXML(r):
+		return p.writeXML(w, http.StatusInternalServerError)
+	default:
+		return p.write(w, http.StatusInternalServerError)
+	}
+}
+
+func acceptsXML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, MediaTypeXML) ||
+		(strings.Contains(accept, "xml") && !strings.Contains(accept, "json"))
+}
+
+func (p *Problem) writeCBOR(w http.ResponseWriter, fallback int) (int, error) {
+	w.Header().Set("Content-Type", MediaTypeCBOR)
+
+	code := fallback
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+	if p != nil {
+		if s, ok := p.data["status"].(int); ok {
+			code = s
+		}
+	}
+	w.WriteHeader(code)
+
+	b, err := cborMarshaler(p.Data())
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(b)
+}
+
+func (p *Problem) writeXML(w http.ResponseWriter, fallback int) (int, error) {
+	w.Header().Set("Content-Type", MediaTypeXML)
+
+	code := fallback
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+	if p != nil {
+		if s, ok := p.data["status"].(int); ok {
+			code = s
+		}
+	}
+	w.WriteHeader(code)
+
+	b, err := xml.Marshal(p)
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(b)
+}
@@ -0,0 +1,28 @@
+package problem
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestMarshalXMLViolationTagsMatchJSON(t *testing.T) {
+	p := New(InvalidParams(Violation{Field: "name", Reason: "required", Code: "required"}))
+
+	b, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+
+	for _, want := range []string{"<field>name</field>", "<reason>required</reason>", "<code>required</code>"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("xml output %q missing %q", out, want)
+		}
+	}
+	for _, unwanted := range []string{"<Field>", "<Reason>", "<Code>"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("xml output %q still uses capitalized Go field name %q", out, unwanted)
+		}
+	}
+}